@@ -0,0 +1,32 @@
+// Package cloudinit parses and applies the cloud-init style bootstrap
+// script delivered to a ByoHost via its BootstrapSecret.
+package cloudinit
+
+// ICmdRunner executes a shell command on the host.
+type ICmdRunner interface {
+	RunCmd(cmd string) error
+	// RunCmdWithOutput runs cmd and returns its combined stdout, for callers
+	// that need to parse the result (e.g. `kubeadm certs check-expiration`).
+	RunCmdWithOutput(cmd string) (string, error)
+}
+
+// IFileWriter writes a file to the host filesystem, creating any parent
+// directories and applying the requested permissions/ownership.
+type IFileWriter interface {
+	MkdirIfNotExists(path string) error
+	WriteToFile(content, path, permissions, owner string) error
+}
+
+// ITemplateParser parses a raw cloud-init bootstrap script into its
+// structured write_files/runcmd phases.
+type ITemplateParser interface {
+	ParseTemplate(bootstrapScript string) (writeFiles []File, runCmd []string, err error)
+}
+
+// File is a single cloud-init write_files entry.
+type File struct {
+	Path        string
+	Content     string
+	Permissions string
+	Owner       string
+}
@@ -0,0 +1,116 @@
+package cloudinit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultJournalPath is where the Journal persists step state between
+// bootstrap attempts, so a retry can resume instead of starting over.
+const DefaultJournalPath = "/var/lib/byoh/bootstrap-journal.json"
+
+// StepRecord is what the Journal remembers about one applied Step.
+type StepRecord struct {
+	// FileHash is the sha256 of the content written for a WriteFile step.
+	FileHash string `json:"fileHash,omitempty"`
+	// Succeeded is true once a RunCmd step has exited zero.
+	Succeeded bool `json:"succeeded"`
+}
+
+// Journal records, per plan step index, what the executor has already
+// applied so a retry can skip steps whose recorded state still matches
+// on-disk reality and only re-run what failed or is missing.
+type Journal struct {
+	path    string
+	Records map[int]StepRecord `json:"records"`
+}
+
+// LoadJournal reads the Journal at path, returning an empty Journal if the
+// file does not exist yet.
+func LoadJournal(path string) (*Journal, error) {
+	j := &Journal{path: path, Records: map[int]StepRecord{}}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return j, nil
+		}
+		return nil, errors.Wrapf(err, "failed to read journal %s", path)
+	}
+
+	if err := json.Unmarshal(data, j); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse journal %s", path)
+	}
+	j.path = path
+	return j, nil
+}
+
+// Save persists the Journal to its path.
+func (j *Journal) Save() error {
+	if err := os.MkdirAll(filepath.Dir(j.path), 0755); err != nil {
+		return errors.Wrapf(err, "failed to create journal directory for %s", j.path)
+	}
+
+	data, err := json.Marshal(j)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal journal")
+	}
+
+	if err := os.WriteFile(j.path, data, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write journal %s", j.path)
+	}
+	return nil
+}
+
+// Remove deletes the journal file once the host is being cleaned up. A
+// successful bootstrap leaves the journal in place for Cleanup to consult.
+func (j *Journal) Remove() error {
+	if err := os.Remove(j.path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to remove journal %s", j.path)
+	}
+	return nil
+}
+
+// IsSatisfied reports whether step at index is already applied: for a
+// WriteFile step, its recorded hash must match the content that would be
+// written now *and* the file must still exist on disk; for a RunCmd step,
+// it must have previously exited zero.
+func (j *Journal) IsSatisfied(index int, step Step) bool {
+	record, ok := j.Records[index]
+	if !ok {
+		return false
+	}
+
+	switch step.Kind {
+	case StepKindWriteFile:
+		if record.FileHash != hashContent(step.File.Content) {
+			return false
+		}
+		_, err := os.Stat(step.File.Path)
+		return err == nil
+	case StepKindRunCmd:
+		return record.Succeeded
+	default:
+		return false
+	}
+}
+
+// MarkWriteFile records that the WriteFile step at index has been applied.
+func (j *Journal) MarkWriteFile(index int, content string) {
+	j.Records[index] = StepRecord{FileHash: hashContent(content)}
+}
+
+// MarkCommandSucceeded records that the RunCmd step at index exited zero.
+func (j *Journal) MarkCommandSucceeded(index int) {
+	j.Records[index] = StepRecord{Succeeded: true}
+}
+
+func hashContent(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,36 @@
+package cloudinit
+
+// StepKind identifies what a Step does when applied.
+type StepKind string
+
+const (
+	StepKindWriteFile StepKind = "WriteFile"
+	StepKindRunCmd    StepKind = "RunCmd"
+)
+
+// Step is a single unit of bootstrap work: either writing one file or
+// running one command. Steps are applied in Plan order.
+type Step struct {
+	Kind StepKind
+	File File
+	Cmd  string
+}
+
+// Plan is the ordered write_files -> runcmd sequence parsed from a
+// bootstrap script. It is the unit the Journal tracks progress against.
+type Plan struct {
+	Steps []Step
+}
+
+// NewPlan builds a Plan in the phased order the request describes:
+// every write_files step first, then every runcmd step.
+func NewPlan(writeFiles []File, runCmd []string) *Plan {
+	plan := &Plan{Steps: make([]Step, 0, len(writeFiles)+len(runCmd))}
+	for _, f := range writeFiles {
+		plan.Steps = append(plan.Steps, Step{Kind: StepKindWriteFile, File: f})
+	}
+	for _, c := range runCmd {
+		plan.Steps = append(plan.Steps, Step{Kind: StepKindRunCmd, Cmd: c})
+	}
+	return plan
+}
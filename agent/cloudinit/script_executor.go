@@ -0,0 +1,146 @@
+package cloudinit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// ProgressFunc is called after every applied (or skipped) step so callers
+// can surface progress, e.g. onto ByoHost.Status.BootstrapProgress.
+type ProgressFunc func(step, total int)
+
+// ScriptExecutor turns a cloud-init bootstrap script into an ordered Plan
+// and applies it step by step, journaling progress to disk so a retry after
+// a partial failure resumes instead of starting over.
+type ScriptExecutor struct {
+	WriteFilesExecutor    IFileWriter
+	RunCmdExecutor        ICmdRunner
+	ParseTemplateExecutor ITemplateParser
+
+	// JournalPath overrides DefaultJournalPath; used by tests.
+	JournalPath string
+}
+
+func (e ScriptExecutor) journalPath() string {
+	if e.JournalPath != "" {
+		return e.JournalPath
+	}
+	return DefaultJournalPath
+}
+
+// Execute parses bootstrapScript into a Plan and applies each step in
+// order, skipping steps the Journal already recorded as satisfied. onProgress
+// may be nil.
+//
+// The Journal is left on disk even after every step succeeds, rather than
+// removed: Cleanup needs it later to know exactly which files this executor
+// created, and a successful bootstrap is the common case Cleanup runs
+// against.
+func (e ScriptExecutor) Execute(ctx context.Context, bootstrapScript string, onProgress ProgressFunc) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	writeFiles, runCmd, err := e.ParseTemplateExecutor.ParseTemplate(bootstrapScript)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse bootstrap script")
+	}
+	plan := NewPlan(writeFiles, runCmd)
+
+	journal, err := LoadJournal(e.journalPath())
+	if err != nil {
+		return err
+	}
+
+	total := len(plan.Steps)
+	for index, step := range plan.Steps {
+		if journal.IsSatisfied(index, step) {
+			log.Info("Skipping already-applied bootstrap step", "step", index, "total", total, "kind", step.Kind)
+			report(onProgress, index+1, total)
+			continue
+		}
+
+		if err := e.applyStep(step); err != nil {
+			_ = journal.Save()
+			return errors.Wrapf(err, "bootstrap step %d/%d (%s) failed", index+1, total, step.Kind)
+		}
+
+		switch step.Kind {
+		case StepKindWriteFile:
+			journal.MarkWriteFile(index, step.File.Content)
+		case StepKindRunCmd:
+			journal.MarkCommandSucceeded(index)
+		}
+
+		if err := journal.Save(); err != nil {
+			return err
+		}
+
+		log.Info("Applied bootstrap step", "step", index+1, "total", total, "kind", step.Kind)
+		report(onProgress, index+1, total)
+	}
+
+	return nil
+}
+
+// Cleanup removes exactly the files this executor's last run wrote,
+// according to the persisted Journal, instead of relying on a blanket
+// kubeadm reset to undo bootstrap artifacts.
+func (e ScriptExecutor) Cleanup(ctx context.Context, bootstrapScript string) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	writeFiles, runCmd, err := e.ParseTemplateExecutor.ParseTemplate(bootstrapScript)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse bootstrap script")
+	}
+	plan := NewPlan(writeFiles, runCmd)
+
+	journal, err := LoadJournal(e.journalPath())
+	if err != nil {
+		return err
+	}
+
+	for index, step := range plan.Steps {
+		if step.Kind != StepKindWriteFile {
+			continue
+		}
+		if _, ok := journal.Records[index]; !ok {
+			continue
+		}
+		log.Info("Removing bootstrap-created file", "path", step.File.Path)
+		if err := removeFile(step.File.Path); err != nil {
+			return err
+		}
+	}
+
+	return journal.Remove()
+}
+
+func (e ScriptExecutor) applyStep(step Step) error {
+	switch step.Kind {
+	case StepKindWriteFile:
+		if err := e.WriteFilesExecutor.MkdirIfNotExists(filepath.Dir(step.File.Path)); err != nil {
+			return err
+		}
+		return e.WriteFilesExecutor.WriteToFile(step.File.Content, step.File.Path, step.File.Permissions, step.File.Owner)
+	case StepKindRunCmd:
+		return e.RunCmdExecutor.RunCmd(step.Cmd)
+	default:
+		return errors.Errorf("unknown step kind %q", step.Kind)
+	}
+}
+
+func report(onProgress ProgressFunc, step, total int) {
+	if onProgress != nil {
+		onProgress(step, total)
+	}
+}
+
+func removeFile(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to remove %s", path)
+	}
+	return nil
+}
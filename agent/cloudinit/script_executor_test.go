@@ -0,0 +1,237 @@
+package cloudinit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// fakeFileWriter performs real file I/O under the test's temp dir, so
+// Journal.IsSatisfied's os.Stat check behaves the same as it would in
+// production.
+type fakeFileWriter struct {
+	mkdirCalls []string
+	failWrite  map[string]bool
+}
+
+func newFakeFileWriter() *fakeFileWriter {
+	return &fakeFileWriter{failWrite: map[string]bool{}}
+}
+
+func (f *fakeFileWriter) MkdirIfNotExists(path string) error {
+	f.mkdirCalls = append(f.mkdirCalls, path)
+	return os.MkdirAll(path, 0755)
+}
+
+func (f *fakeFileWriter) WriteToFile(content, path, permissions, owner string) error {
+	if f.failWrite[path] {
+		return errors.New("write failed")
+	}
+	return os.WriteFile(path, []byte(content), 0644)
+}
+
+type fakeCmdRunner struct {
+	ran      []string
+	failCmds map[string]bool
+}
+
+func newFakeCmdRunner() *fakeCmdRunner {
+	return &fakeCmdRunner{failCmds: map[string]bool{}}
+}
+
+func (f *fakeCmdRunner) RunCmd(cmd string) error {
+	f.ran = append(f.ran, cmd)
+	if f.failCmds[cmd] {
+		return errors.New("command failed")
+	}
+	return nil
+}
+
+func (f *fakeCmdRunner) RunCmdWithOutput(cmd string) (string, error) {
+	return "", f.RunCmd(cmd)
+}
+
+type fakeTemplateParser struct {
+	writeFiles []File
+	runCmd     []string
+}
+
+func (f *fakeTemplateParser) ParseTemplate(string) ([]File, []string, error) {
+	return f.writeFiles, f.runCmd, nil
+}
+
+func newExecutor(t *testing.T, writer *fakeFileWriter, runner *fakeCmdRunner, parser *fakeTemplateParser) ScriptExecutor {
+	t.Helper()
+	return ScriptExecutor{
+		WriteFilesExecutor:    writer,
+		RunCmdExecutor:        runner,
+		ParseTemplateExecutor: parser,
+		JournalPath:           filepath.Join(t.TempDir(), "journal.json"),
+	}
+}
+
+func TestExecuteAppliesEveryStepOnce(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "kubelet.conf")
+
+	writer := newFakeFileWriter()
+	runner := newFakeCmdRunner()
+	parser := &fakeTemplateParser{
+		writeFiles: []File{{Path: filePath, Content: "a"}},
+		runCmd:     []string{"systemctl restart kubelet"},
+	}
+	executor := newExecutor(t, writer, runner, parser)
+
+	if err := executor.Execute(context.Background(), "script", nil); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil || string(content) != "a" {
+		t.Fatalf("expected file to be written with %q, got %q, err %v", "a", content, err)
+	}
+	if len(runner.ran) != 1 || runner.ran[0] != "systemctl restart kubelet" {
+		t.Fatalf("expected command to run once, got %v", runner.ran)
+	}
+	if _, err := os.Stat(executor.JournalPath); err != nil {
+		t.Fatalf("expected journal to survive a successful run so Cleanup can later consult it, stat err = %v", err)
+	}
+}
+
+func TestCleanupRemovesFilesWrittenByASuccessfulRun(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "kubelet.conf")
+
+	writer := newFakeFileWriter()
+	runner := newFakeCmdRunner()
+	parser := &fakeTemplateParser{
+		writeFiles: []File{{Path: filePath, Content: "a"}},
+		runCmd:     []string{"systemctl restart kubelet"},
+	}
+	executor := newExecutor(t, writer, runner, parser)
+
+	if err := executor.Execute(context.Background(), "script", nil); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if _, err := os.Stat(filePath); err != nil {
+		t.Fatalf("test setup: expected file to exist after Execute, stat err = %v", err)
+	}
+
+	if err := executor.Cleanup(context.Background(), "script"); err != nil {
+		t.Fatalf("Cleanup() error = %v", err)
+	}
+
+	if _, err := os.Stat(filePath); !os.IsNotExist(err) {
+		t.Fatalf("expected Cleanup to remove the file a successful run wrote, stat err = %v", err)
+	}
+	if _, err := os.Stat(executor.JournalPath); !os.IsNotExist(err) {
+		t.Fatalf("expected Cleanup to remove the journal once done, stat err = %v", err)
+	}
+}
+
+func TestExecuteResumesAfterFailureWithoutRedoingSatisfiedSteps(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "kubelet.conf")
+
+	writer := newFakeFileWriter()
+	runner := newFakeCmdRunner()
+	parser := &fakeTemplateParser{
+		writeFiles: []File{{Path: filePath, Content: "a"}},
+		runCmd:     []string{"kubeadm init phase 1", "kubeadm init phase 2"},
+	}
+	executor := newExecutor(t, writer, runner, parser)
+
+	runner.failCmds["kubeadm init phase 1"] = true
+	if err := executor.Execute(context.Background(), "script", nil); err == nil {
+		t.Fatal("expected Execute() to fail on the first bad command")
+	}
+	if len(runner.ran) != 1 {
+		t.Fatalf("expected exactly one command attempt, got %v", runner.ran)
+	}
+
+	// Fix the command and retry: the already-written file step must not be
+	// reapplied, and execution should resume from the failed command.
+	runner.failCmds["kubeadm init phase 1"] = false
+	runner.ran = nil
+	writer.failWrite[filePath] = true // would fail loudly if re-applied
+
+	if err := executor.Execute(context.Background(), "script", nil); err != nil {
+		t.Fatalf("Execute() error on retry = %v", err)
+	}
+	if len(runner.ran) != 2 || runner.ran[0] != "kubeadm init phase 1" || runner.ran[1] != "kubeadm init phase 2" {
+		t.Fatalf("expected both commands to run on retry, got %v", runner.ran)
+	}
+}
+
+func TestExecuteRewritesFileIfContentChangedSinceLastRun(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "kubelet.conf")
+
+	writer := newFakeFileWriter()
+	runner := newFakeCmdRunner()
+	parser := &fakeTemplateParser{
+		writeFiles: []File{{Path: filePath, Content: "a"}},
+	}
+	executor := newExecutor(t, writer, runner, parser)
+
+	if err := executor.Execute(context.Background(), "script", nil); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	parser.writeFiles[0].Content = "b"
+	if err := executor.Execute(context.Background(), "script", nil); err != nil {
+		t.Fatalf("Execute() error on content change = %v", err)
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil || string(content) != "b" {
+		t.Fatalf("expected changed content to be rewritten, got %q, err %v", content, err)
+	}
+}
+
+func TestExecuteRewritesFileIfRemovedSinceLastRun(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "kubelet.conf")
+
+	writer := newFakeFileWriter()
+	runner := newFakeCmdRunner()
+	parser := &fakeTemplateParser{
+		writeFiles: []File{{Path: filePath, Content: "a"}},
+	}
+	executor := newExecutor(t, writer, runner, parser)
+
+	if err := executor.Execute(context.Background(), "script", nil); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if err := os.Remove(filePath); err != nil {
+		t.Fatalf("failed to remove file for test setup: %v", err)
+	}
+
+	if err := executor.Execute(context.Background(), "script", nil); err != nil {
+		t.Fatalf("Execute() error after file removed = %v", err)
+	}
+	if _, err := os.Stat(filePath); err != nil {
+		t.Fatalf("expected file to be rewritten after being removed, stat err = %v", err)
+	}
+}
+
+func TestApplyStepCreatesFileParentDirectoryNotFilePath(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "pki", "ca.crt")
+
+	writer := newFakeFileWriter()
+	runner := newFakeCmdRunner()
+	executor := newExecutor(t, writer, runner, &fakeTemplateParser{})
+
+	step := Step{Kind: StepKindWriteFile, File: File{Path: filePath, Content: "cert"}}
+	if err := executor.applyStep(step); err != nil {
+		t.Fatalf("applyStep() error = %v", err)
+	}
+	if len(writer.mkdirCalls) != 1 || writer.mkdirCalls[0] != filepath.Join(dir, "pki") {
+		t.Fatalf("expected MkdirIfNotExists to be called with the parent directory, got %v", writer.mkdirCalls)
+	}
+}
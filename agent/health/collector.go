@@ -0,0 +1,182 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	infrastructurev1alpha4 "github.com/vmware-tanzu/cluster-api-provider-byoh/apis/infrastructure/v1alpha4"
+	"golang.org/x/sys/unix"
+)
+
+const kubeletHealthzURL = "http://localhost:10248/healthz"
+
+// collectHostInfo gathers the signals that make up ByoHost.Status.HostInfo.
+// It returns a best-effort HostInfo even on error: every signal is collected
+// independently, so one missing source (e.g. /etc/os-release) does not
+// discard the rest.
+func collectHostInfo(ctx context.Context, kubeletExpected bool) (infrastructurev1alpha4.HostInfo, error) {
+	info := infrastructurev1alpha4.HostInfo{}
+	var errMsgs []string
+
+	if v, err := kernelVersion(); err != nil {
+		errMsgs = append(errMsgs, err.Error())
+	} else {
+		info.KernelVersion = v
+	}
+
+	if v, err := osImage(); err != nil {
+		errMsgs = append(errMsgs, err.Error())
+	} else {
+		info.OSImage = v
+	}
+
+	info.CPUPressure, info.MemoryPressure, info.DiskPressure = resourcePressure()
+	info.ContainerRuntimeVersion = containerRuntimeVersion()
+	info.NetworkInterfaces = reachableInterfaces()
+
+	if kubeletExpected {
+		info.KubeletHealthy = probeKubeletHealthz(ctx)
+	}
+
+	if len(errMsgs) > 0 {
+		return info, fmt.Errorf("collecting host info: %s", strings.Join(errMsgs, "; "))
+	}
+	return info, nil
+}
+
+func kernelVersion() (string, error) {
+	var uts unix.Utsname
+	if err := unix.Uname(&uts); err != nil {
+		return "", fmt.Errorf("failed to read kernel version: %w", err)
+	}
+	return unix.ByteSliceToString(uts.Release[:]), nil
+}
+
+func osImage() (string, error) {
+	data, err := os.ReadFile("/etc/os-release")
+	if err != nil {
+		return "", fmt.Errorf("failed to read /etc/os-release: %w", err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if name, ok := strings.CutPrefix(line, "PRETTY_NAME="); ok {
+			return strings.Trim(name, `"`), nil
+		}
+	}
+	return "", nil
+}
+
+// resourcePressure reports whether the host is under CPU, memory or disk
+// pressure. The agent errs on the side of under- rather than over-reporting:
+// a failure to read a given signal is treated as "no pressure" rather than
+// failing the whole collection pass.
+func resourcePressure() (cpu, memory, disk bool) {
+	if load, err := oneMinuteLoadAverage(); err == nil {
+		cpu = load/float64(runtime.NumCPU()) > 1.0
+	}
+
+	var sysinfo unix.Sysinfo_t
+	if err := unix.Sysinfo(&sysinfo); err == nil && sysinfo.Totalram > 0 {
+		memory = float64(sysinfo.Freeram)/float64(sysinfo.Totalram) < 0.1
+	}
+
+	var stat unix.Statfs_t
+	if err := unix.Statfs("/", &stat); err == nil {
+		free := stat.Bavail * uint64(stat.Bsize)
+		total := stat.Blocks * uint64(stat.Bsize)
+		if total > 0 {
+			disk = float64(free)/float64(total) < 0.1
+		}
+	}
+	return cpu, memory, disk
+}
+
+// oneMinuteLoadAverage reads the first field of /proc/loadavg.
+func oneMinuteLoadAverage() (float64, error) {
+	data, err := os.ReadFile("/proc/loadavg")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read /proc/loadavg: %w", err)
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("unexpected /proc/loadavg format %q", string(data))
+	}
+	return strconv.ParseFloat(fields[0], 64)
+}
+
+// containerRuntimeBinaries maps each runtime's well-known control socket to
+// the CLI that can report its version.
+var containerRuntimeBinaries = map[string]string{
+	"/run/containerd/containerd.sock": "containerd",
+	"/var/run/dockershim.sock":        "docker",
+	"/var/run/crio/crio.sock":         "crio",
+}
+
+func containerRuntimeVersion() string {
+	for _, socket := range []string{"/run/containerd/containerd.sock", "/var/run/dockershim.sock", "/var/run/crio/crio.sock"} {
+		if _, err := os.Stat(socket); err != nil {
+			continue
+		}
+		out, err := exec.Command(containerRuntimeBinaries[socket], "--version").Output()
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSpace(string(out))
+	}
+	return ""
+}
+
+func reachableInterfaces() []string {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil
+	}
+
+	var reachable []string
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil || len(addrs) == 0 {
+			continue
+		}
+		reachable = append(reachable, iface.Name)
+	}
+	return reachable
+}
+
+func probeKubeletHealthz(ctx context.Context) bool {
+	client := &http.Client{Timeout: 5 * time.Second}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, kubeletHealthzURL, nil)
+	if err != nil {
+		return false
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
+
+func unhealthyReason(info infrastructurev1alpha4.HostInfo, kubeletExpected bool) (string, bool) {
+	switch {
+	case info.DiskPressure:
+		return "host is under disk pressure", true
+	case info.MemoryPressure:
+		return "host is under memory pressure", true
+	case info.CPUPressure:
+		return "host is under CPU pressure", true
+	case kubeletExpected && !info.KubeletHealthy:
+		return "kubelet healthz probe is failing", true
+	}
+	return "", false
+}
@@ -0,0 +1,103 @@
+// Package health periodically reports host inventory and health signals
+// onto the agent's ByoHost, borrowing the fixed-interval scheduled job
+// pattern used by periodic resource-sync controllers.
+package health
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vmware-tanzu/cluster-api-provider-byoh/agent/cloudinit"
+	"github.com/vmware-tanzu/cluster-api-provider-byoh/agent/reconciler"
+	infrastructurev1alpha4 "github.com/vmware-tanzu/cluster-api-provider-byoh/apis/infrastructure/v1alpha4"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// defaultReportInterval is used when the agent is started without an
+// explicit --host-status-report-interval flag.
+const defaultReportInterval = 30 * time.Second
+
+// HostStatusReporter is a controller-runtime Runnable that keeps
+// ByoHost.Status.HostInfo and the HostHealthy condition fresh, giving the
+// CAPI side visibility into a host before any Machine is ever bound to it.
+type HostStatusReporter struct {
+	Client    client.Client
+	CmdRunner cloudinit.ICmdRunner
+	HostKey   types.NamespacedName
+	Interval  time.Duration
+}
+
+// NewHostStatusReporter builds a HostStatusReporter for the given ByoHost,
+// defaulting Interval when it is unset.
+func NewHostStatusReporter(c client.Client, cmdRunner cloudinit.ICmdRunner, hostKey types.NamespacedName, interval time.Duration) *HostStatusReporter {
+	if interval <= 0 {
+		interval = defaultReportInterval
+	}
+	return &HostStatusReporter{Client: c, CmdRunner: cmdRunner, HostKey: hostKey, Interval: interval}
+}
+
+// Start implements manager.Runnable so the reporter shares the manager's
+// leader election and lifecycle with HostReconciler.
+func (h *HostStatusReporter) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	ticker := time.NewTicker(h.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := h.report(ctx); err != nil {
+				log.Error(err, "failed to report host status")
+			}
+		}
+	}
+}
+
+func (h *HostStatusReporter) report(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	byoHost := &infrastructurev1alpha4.ByoHost{}
+	if err := h.Client.Get(ctx, h.HostKey, byoHost); err != nil {
+		return errors.Wrap(err, "failed to get ByoHost")
+	}
+
+	helper, err := patch.NewHelper(byoHost, h.Client)
+	if err != nil {
+		return errors.Wrap(err, "failed to create patch helper")
+	}
+
+	kubeletExpected := bootstrapped(byoHost)
+	info, collectErr := collectHostInfo(ctx, kubeletExpected)
+	byoHost.Status.HostInfo = info
+
+	if collectErr != nil {
+		conditions.MarkFalse(byoHost, infrastructurev1alpha4.HostHealthy, infrastructurev1alpha4.HostInfoUnavailableReason, v1alpha4.ConditionSeverityWarning, collectErr.Error())
+	} else if reason, unhealthy := unhealthyReason(info, kubeletExpected); unhealthy {
+		conditions.MarkFalse(byoHost, infrastructurev1alpha4.HostHealthy, infrastructurev1alpha4.HostDegradedReason, v1alpha4.ConditionSeverityWarning, reason)
+	} else {
+		conditions.MarkTrue(byoHost, infrastructurev1alpha4.HostHealthy)
+	}
+
+	if isControlPlane, err := reconciler.IsControlPlaneHost(ctx, h.Client, byoHost); err != nil {
+		log.Error(err, "failed to determine whether host is control-plane")
+	} else if isControlPlane {
+		if err := reconciler.RefreshCertificateExpiry(h.CmdRunner, byoHost); err != nil {
+			log.Error(err, "failed to refresh certificate expiry")
+		}
+	}
+
+	return helper.Patch(ctx, byoHost)
+}
+
+func bootstrapped(byoHost *infrastructurev1alpha4.ByoHost) bool {
+	return conditions.IsTrue(byoHost, infrastructurev1alpha4.K8sNodeBootstrapSucceeded)
+}
@@ -0,0 +1,28 @@
+// Package hooks lets operators run site-specific logic around the agent's
+// bootstrap and cleanup steps, modelled after Cluster API's Runtime SDK
+// extension points.
+package hooks
+
+import (
+	"context"
+
+	infrastructurev1alpha4 "github.com/vmware-tanzu/cluster-api-provider-byoh/apis/infrastructure/v1alpha4"
+)
+
+// Phase identifies where in the reconcile flow a BootstrapHook is invoked.
+type Phase string
+
+const (
+	PreBootstrap  Phase = "PreBootstrap"
+	PostBootstrap Phase = "PostBootstrap"
+	PreCleanup    Phase = "PreCleanup"
+	PostCleanup   Phase = "PostCleanup"
+)
+
+// BootstrapHook is invoked around bootstrapK8sNode and hostCleanUp, letting
+// operators inject site-specific setup (proxy config, kernel params, LUKS
+// unlock, custom CA trust) without patching the agent itself.
+type BootstrapHook interface {
+	Name() string
+	Run(ctx context.Context, byoHost *infrastructurev1alpha4.ByoHost, phase Phase) error
+}
@@ -0,0 +1,79 @@
+package hooks
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	infrastructurev1alpha4 "github.com/vmware-tanzu/cluster-api-provider-byoh/apis/infrastructure/v1alpha4"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// ScriptDirHook runs every executable file under Dir/<phase-dir>, in
+// lexical order, similar to kubelet's drop-in config directories
+// (e.g. /etc/byoh/hooks.d/pre-bootstrap/*).
+type ScriptDirHook struct {
+	// Dir is the root hooks directory, e.g. /etc/byoh/hooks.d.
+	Dir string
+}
+
+var _ BootstrapHook = &ScriptDirHook{}
+
+func (h *ScriptDirHook) Name() string {
+	return "script-dir:" + h.Dir
+}
+
+func (h *ScriptDirHook) Run(ctx context.Context, byoHost *infrastructurev1alpha4.ByoHost, phase Phase) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	phaseDir := filepath.Join(h.Dir, phaseDirName(phase))
+	entries, err := os.ReadDir(phaseDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to read hook directory %s", phaseDir)
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		scriptPath := filepath.Join(phaseDir, entry.Name())
+		info, err := entry.Info()
+		if err != nil || info.Mode()&0111 == 0 {
+			// Skip files that are not executable, same as a kubelet drop-in dir.
+			continue
+		}
+
+		log.Info("Running bootstrap hook script", "path", scriptPath, "phase", phase)
+		cmd := exec.CommandContext(ctx, scriptPath)
+		cmd.Env = append(os.Environ(), "BYOH_HOST_NAME="+byoHost.Name, "BYOH_HOOK_PHASE="+string(phase))
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return errors.Wrapf(err, "hook script %s failed: %s", scriptPath, out)
+		}
+	}
+
+	return nil
+}
+
+func phaseDirName(phase Phase) string {
+	switch phase {
+	case PreBootstrap:
+		return "pre-bootstrap"
+	case PostBootstrap:
+		return "post-bootstrap"
+	case PreCleanup:
+		return "pre-cleanup"
+	case PostCleanup:
+		return "post-cleanup"
+	default:
+		return string(phase)
+	}
+}
@@ -0,0 +1,63 @@
+// Package log provides the agent's context-scoped structured logger. It
+// replaces ad hoc klog.* call sites with a logr.Logger threaded through
+// context.Context, so every log line can be correlated back to the
+// reconcile request / host that produced it.
+//
+// The agent's main.go is expected to call Options.BindFlags on its
+// flag.FlagSet, build the root logger with New once flags are parsed, and
+// seed the manager's base context with it (e.g. via ctrl.SetLogger and
+// ctrl.LoggerInto) before starting HostReconciler and HostStatusReporter, so
+// both pick it up through the ctrl.LoggerFrom calls they already make.
+package log
+
+import (
+	"context"
+	"flag"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	"k8s.io/klog/v2"
+	"k8s.io/klog/v2/klogr"
+)
+
+// Options configures the agent's klog backend. It is bound to agent
+// command-line flags at startup, mirroring how controller-runtime wires
+// zap.Options.
+type Options struct {
+	// LogLevel is the klog -v verbosity level.
+	LogLevel int
+	// AddDirHeader controls whether klog prefixes log lines with the
+	// originating source directory; New passes it to klog's add_dir_header flag.
+	AddDirHeader bool
+}
+
+// BindFlags registers the agent's logging flags on fs.
+func (o *Options) BindFlags(fs *flag.FlagSet) {
+	fs.IntVar(&o.LogLevel, "v", 0, "number for the log level verbosity")
+	fs.BoolVar(&o.AddDirHeader, "add-dir-header", false, "add the file directory to the header of log messages")
+}
+
+// New builds the root logr.Logger for the agent from Options, configuring
+// the underlying klog backend first.
+func New(o Options) logr.Logger {
+	klog.InitFlags(nil)
+	_ = flag.Set("v", strconv.Itoa(o.LogLevel))
+	_ = flag.Set("add_dir_header", strconv.FormatBool(o.AddDirHeader))
+	return klogr.New()
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying logger.
+func NewContext(ctx context.Context, logger logr.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the logger stored in ctx by NewContext, or a no-op
+// discard logger if none was set.
+func FromContext(ctx context.Context) logr.Logger {
+	if logger, ok := ctx.Value(contextKey{}).(logr.Logger); ok {
+		return logger
+	}
+	return logr.Discard()
+}
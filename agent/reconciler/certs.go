@@ -0,0 +1,158 @@
+package reconciler
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vmware-tanzu/cluster-api-provider-byoh/agent/cloudinit"
+	infrastructurev1alpha4 "github.com/vmware-tanzu/cluster-api-provider-byoh/apis/infrastructure/v1alpha4"
+	"gopkg.in/yaml.v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/cluster-api/api/v1alpha4"
+	clusterv1labels "sigs.k8s.io/cluster-api/api/v1alpha4/labels"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	kubeadmCertsRenewAllCommand        = "kubeadm certs renew all"
+	kubeadmCertsCheckExpirationCommand = "kubeadm certs check-expiration -o yaml"
+
+	// staticPodManifestDir is where the kubelet watches for control-plane
+	// static pod manifests; touching a file there forces the kubelet to
+	// restart the corresponding container with the freshly renewed certs.
+	staticPodManifestDir = "/etc/kubernetes/manifests"
+
+	// certExpiryWarningWindow is how close to expiry a certificate can get
+	// before CertificatesReady is marked False with Warning severity.
+	certExpiryWarningWindow = 30 * 24 * time.Hour
+)
+
+// certExpirationReport mirrors the subset of `kubeadm certs check-expiration
+// -o yaml` output the agent cares about.
+type certExpirationReport struct {
+	CertificateExpirationInfo []struct {
+		ExpirationDate time.Time `yaml:"expirationDate"`
+	} `yaml:"certificateExpirationInfo"`
+}
+
+// reconcileCertRenewal handles the renew-certs annotation for control-plane
+// ByoHosts: it renews all kubeadm-issued certificates, restarts the static
+// control-plane pods so they pick up the new certs, and records the new
+// expiry on the ByoHost.
+func (r *HostReconciler) reconcileCertRenewal(ctx context.Context, byoHost *infrastructurev1alpha4.ByoHost) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	isControlPlane, err := IsControlPlaneHost(ctx, r.Client, byoHost)
+	if err != nil {
+		return err
+	}
+	if !isControlPlane {
+		delete(byoHost.Annotations, infrastructurev1alpha4.RenewCertsAnnotation)
+		return nil
+	}
+
+	log.Info("Renewing control-plane certificates...")
+	if err := r.CmdRunner.RunCmd(kubeadmCertsRenewAllCommand); err != nil {
+		conditions.MarkFalse(byoHost, infrastructurev1alpha4.CertificatesReady, infrastructurev1alpha4.CertificateRenewalFailedReason, v1alpha4.ConditionSeverityError, err.Error())
+		return errors.Wrap(err, "failed to renew certificates")
+	}
+
+	if err := restartStaticControlPlanePods(); err != nil {
+		conditions.MarkFalse(byoHost, infrastructurev1alpha4.CertificatesReady, infrastructurev1alpha4.CertificateRenewalFailedReason, v1alpha4.ConditionSeverityError, err.Error())
+		return errors.Wrap(err, "failed to restart static control-plane pods")
+	}
+
+	if err := RefreshCertificateExpiry(r.CmdRunner, byoHost); err != nil {
+		return err
+	}
+
+	delete(byoHost.Annotations, infrastructurev1alpha4.RenewCertsAnnotation)
+	log.Info("Control-plane certificates renewed")
+	return nil
+}
+
+// RefreshCertificateExpiry updates ByoHost.Status.CertificatesExpiryDate and
+// the CertificatesReady condition from the host's current certificates,
+// independent of whether a renewal just ran. It is exported so the health
+// reporter's periodic loop can keep the expiry fresh between renewals.
+func RefreshCertificateExpiry(cmdRunner cloudinit.ICmdRunner, byoHost *infrastructurev1alpha4.ByoHost) error {
+	out, err := cmdRunner.RunCmdWithOutput(kubeadmCertsCheckExpirationCommand)
+	if err != nil {
+		return errors.Wrap(err, "failed to check certificate expiration")
+	}
+
+	var report certExpirationReport
+	if err := yaml.Unmarshal([]byte(out), &report); err != nil {
+		return errors.Wrap(err, "failed to parse kubeadm certs check-expiration output")
+	}
+
+	expiry := earliestExpiry(report)
+	if expiry.IsZero() {
+		return nil
+	}
+
+	byoHost.Status.CertificatesExpiryDate = &metav1.Time{Time: expiry}
+
+	if time.Until(expiry) < certExpiryWarningWindow {
+		conditions.MarkFalse(byoHost, infrastructurev1alpha4.CertificatesReady, infrastructurev1alpha4.CertificatesExpiringReason, v1alpha4.ConditionSeverityWarning,
+			"certificates expire on %s", expiry.Format(time.RFC3339))
+	} else {
+		conditions.MarkTrue(byoHost, infrastructurev1alpha4.CertificatesReady)
+	}
+
+	return nil
+}
+
+func earliestExpiry(report certExpirationReport) time.Time {
+	var earliest time.Time
+	for _, cert := range report.CertificateExpirationInfo {
+		if earliest.IsZero() || cert.ExpirationDate.Before(earliest) {
+			earliest = cert.ExpirationDate
+		}
+	}
+	return earliest
+}
+
+// IsControlPlaneHost reports whether byoHost's bound Machine is a
+// control-plane machine. It is exported so the health reporter can decide
+// whether to refresh certificate expiry for a given host.
+func IsControlPlaneHost(ctx context.Context, c client.Client, byoHost *infrastructurev1alpha4.ByoHost) (bool, error) {
+	if byoHost.Status.MachineRef == nil {
+		return false, nil
+	}
+
+	machine := &v1alpha4.Machine{}
+	if err := c.Get(ctx, types.NamespacedName{Name: byoHost.Status.MachineRef.Name, Namespace: byoHost.Status.MachineRef.Namespace}, machine); err != nil {
+		return false, errors.Wrap(err, "failed to get Machine for ByoHost")
+	}
+
+	_, ok := machine.Labels[clusterv1labels.MachineControlPlaneLabelName]
+	return ok, nil
+}
+
+// restartStaticControlPlanePods touches every static pod manifest so the
+// kubelet recreates the corresponding containers with the renewed certs.
+func restartStaticControlPlanePods() error {
+	entries, err := os.ReadDir(staticPodManifestDir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read static pod manifest dir %s", staticPodManifestDir)
+	}
+
+	now := time.Now()
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(staticPodManifestDir, entry.Name())
+		if err := os.Chtimes(path, now, now); err != nil {
+			return errors.Wrapf(err, "failed to touch manifest %s", path)
+		}
+	}
+	return nil
+}
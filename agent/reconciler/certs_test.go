@@ -0,0 +1,90 @@
+package reconciler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	infrastructurev1alpha4 "github.com/vmware-tanzu/cluster-api-provider-byoh/apis/infrastructure/v1alpha4"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+type fakeCertCmdRunner struct {
+	output string
+	err    error
+}
+
+func (f *fakeCertCmdRunner) RunCmd(cmd string) error { return nil }
+
+func (f *fakeCertCmdRunner) RunCmdWithOutput(cmd string) (string, error) {
+	return f.output, f.err
+}
+
+func TestEarliestExpiryReturnsTheSoonestDate(t *testing.T) {
+	soonest := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	latest := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	report := certExpirationReport{
+		CertificateExpirationInfo: []struct {
+			ExpirationDate time.Time `yaml:"expirationDate"`
+		}{
+			{ExpirationDate: latest},
+			{ExpirationDate: soonest},
+		},
+	}
+
+	if got := earliestExpiry(report); !got.Equal(soonest) {
+		t.Fatalf("earliestExpiry() = %v, want %v", got, soonest)
+	}
+}
+
+func TestEarliestExpiryReturnsZeroValueForEmptyReport(t *testing.T) {
+	if got := earliestExpiry(certExpirationReport{}); !got.IsZero() {
+		t.Fatalf("earliestExpiry() = %v, want zero value", got)
+	}
+}
+
+func TestRefreshCertificateExpiryMarksReadyWhenOutsideWarningWindow(t *testing.T) {
+	// RFC3339 carries whole seconds only, so compare against a value the
+	// parsed output can actually represent.
+	expiry := time.Now().Add(90 * 24 * time.Hour).Truncate(time.Second)
+	runner := &fakeCertCmdRunner{output: "certificateExpirationInfo:\n- expirationDate: " + expiry.Format(time.RFC3339) + "\n"}
+	byoHost := &infrastructurev1alpha4.ByoHost{}
+
+	if err := RefreshCertificateExpiry(runner, byoHost); err != nil {
+		t.Fatalf("RefreshCertificateExpiry() error = %v", err)
+	}
+
+	if byoHost.Status.CertificatesExpiryDate == nil || !byoHost.Status.CertificatesExpiryDate.Time.Equal(expiry) {
+		t.Fatalf("expected CertificatesExpiryDate to be set to %v, got %v", expiry, byoHost.Status.CertificatesExpiryDate)
+	}
+	if !conditions.IsTrue(byoHost, infrastructurev1alpha4.CertificatesReady) {
+		t.Fatalf("expected CertificatesReady to be true, got %+v", conditions.Get(byoHost, infrastructurev1alpha4.CertificatesReady))
+	}
+}
+
+func TestRefreshCertificateExpiryWarnsInsideWarningWindow(t *testing.T) {
+	expiry := time.Now().Add(7 * 24 * time.Hour)
+	runner := &fakeCertCmdRunner{output: "certificateExpirationInfo:\n- expirationDate: " + expiry.Format(time.RFC3339) + "\n"}
+	byoHost := &infrastructurev1alpha4.ByoHost{}
+
+	if err := RefreshCertificateExpiry(runner, byoHost); err != nil {
+		t.Fatalf("RefreshCertificateExpiry() error = %v", err)
+	}
+
+	condition := conditions.Get(byoHost, infrastructurev1alpha4.CertificatesReady)
+	if condition == nil || conditions.IsTrue(byoHost, infrastructurev1alpha4.CertificatesReady) {
+		t.Fatalf("expected CertificatesReady to be false within the warning window, got %+v", condition)
+	}
+	if condition.Reason != infrastructurev1alpha4.CertificatesExpiringReason {
+		t.Fatalf("expected reason %q, got %q", infrastructurev1alpha4.CertificatesExpiringReason, condition.Reason)
+	}
+}
+
+func TestRefreshCertificateExpiryPropagatesCommandError(t *testing.T) {
+	runner := &fakeCertCmdRunner{err: errors.New("kubeadm not found")}
+	byoHost := &infrastructurev1alpha4.ByoHost{}
+
+	if err := RefreshCertificateExpiry(runner, byoHost); err == nil {
+		t.Fatal("expected RefreshCertificateExpiry() to propagate the command error")
+	}
+}
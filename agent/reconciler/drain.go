@@ -0,0 +1,125 @@
+package reconciler
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	infrastructurev1alpha4 "github.com/vmware-tanzu/cluster-api-provider-byoh/apis/infrastructure/v1alpha4"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/cluster-api/api/v1alpha4"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	kubernetesdrain "sigs.k8s.io/cluster-api/third_party/kubernetes-drain"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// drainRequeueAfter is how soon a ByoHost that is still waiting on pod
+// eviction is requeued, so that PodDisruptionBudgets get a chance to clear.
+const drainRequeueAfter = 20 * time.Second
+
+// drainNode cordons and evicts the workload cluster node backed by byoHost,
+// honouring byoHost.Spec.NodeDrainTimeout. It reports (true, ...) once it is
+// safe to proceed to resetNode: either the drain finished, the host was never
+// bound to a node, or the timeout elapsed. A (false, ...) result means the
+// caller should requeue and try again later.
+func (r *HostReconciler) drainNode(ctx context.Context, byoHost *infrastructurev1alpha4.ByoHost) (bool, ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if byoHost.Status.MachineRef == nil {
+		return true, ctrl.Result{}, nil
+	}
+
+	machine := &v1alpha4.Machine{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: byoHost.Status.MachineRef.Name, Namespace: byoHost.Status.MachineRef.Namespace}, machine); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, ctrl.Result{}, nil
+		}
+		return false, ctrl.Result{}, errors.Wrap(err, "failed to get Machine for ByoHost")
+	}
+
+	if machine.Status.NodeRef == nil {
+		// Kubelet never registered a Node, so there is nothing to drain.
+		return true, ctrl.Result{}, nil
+	}
+
+	cluster := &v1alpha4.Cluster{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: machine.Spec.ClusterName, Namespace: machine.Namespace}, cluster); err != nil {
+		return false, ctrl.Result{}, errors.Wrap(err, "failed to get Cluster for ByoHost's Machine")
+	}
+
+	// A nil or zero NodeDrainTimeout means wait indefinitely, matching
+	// Machine.Spec.NodeDrainTimeout upstream: it must never be treated as
+	// "skip draining".
+	var timeout time.Duration
+	hasDeadline := byoHost.Spec.NodeDrainTimeout != nil && byoHost.Spec.NodeDrainTimeout.Duration > 0
+	if hasDeadline {
+		timeout = byoHost.Spec.NodeDrainTimeout.Duration
+	}
+
+	drainingCondition := conditions.Get(byoHost, infrastructurev1alpha4.DrainingSucceededCondition)
+	if drainingCondition == nil {
+		conditions.MarkFalse(byoHost, infrastructurev1alpha4.DrainingSucceededCondition, infrastructurev1alpha4.DrainingReason, v1alpha4.ConditionSeverityInfo, "Draining node")
+		drainingCondition = conditions.Get(byoHost, infrastructurev1alpha4.DrainingSucceededCondition)
+	}
+
+	if hasDeadline {
+		if elapsed := time.Since(drainingCondition.LastTransitionTime.Time); elapsed >= timeout {
+			log.Info("Node drain timeout elapsed, proceeding with reset", "timeout", timeout)
+			conditions.MarkTrue(byoHost, infrastructurev1alpha4.DrainingSucceededCondition)
+			return true, ctrl.Result{}, nil
+		}
+	}
+
+	restConfig, err := remote.RESTConfig(ctx, "byoh", r.Client, client.ObjectKeyFromObject(cluster))
+	if err != nil {
+		return false, ctrl.Result{}, errors.Wrap(err, "failed to get workload cluster REST config")
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return false, ctrl.Result{}, errors.Wrap(err, "failed to build workload cluster clientset")
+	}
+
+	node, err := kubeClient.CoreV1().Nodes().Get(ctx, machine.Status.NodeRef.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			conditions.MarkTrue(byoHost, infrastructurev1alpha4.DrainingSucceededCondition)
+			return true, ctrl.Result{}, nil
+		}
+		return false, ctrl.Result{}, errors.Wrap(err, "failed to get workload cluster Node")
+	}
+
+	if err := kubernetesdrain.RunCordonOrUncordon(kubeClient, node, true); err != nil {
+		return false, ctrl.Result{}, errors.Wrap(err, "failed to cordon node")
+	}
+
+	// A zero Helper.Timeout means kubernetesdrain waits indefinitely, so
+	// leave it unset when byoHost has no deadline of its own.
+	var remaining time.Duration
+	if hasDeadline {
+		remaining = timeout - time.Since(drainingCondition.LastTransitionTime.Time)
+	}
+	helper := &kubernetesdrain.Helper{
+		Client:              kubeClient,
+		Ctx:                 ctx,
+		Force:               true,
+		IgnoreAllDaemonSets: true,
+		DeleteEmptyDirData:  true,
+		GracePeriodSeconds:  -1,
+		Timeout:             remaining,
+	}
+
+	if err := kubernetesdrain.RunNodeDrain(helper, node.Name); err != nil {
+		log.Info("Node drain in progress, will retry", "node", node.Name, "remaining", remaining)
+		return false, ctrl.Result{RequeueAfter: drainRequeueAfter}, nil
+	}
+
+	log.Info("Node drained successfully", "node", node.Name)
+	conditions.MarkTrue(byoHost, infrastructurev1alpha4.DrainingSucceededCondition)
+	return true, ctrl.Result{}, nil
+}
@@ -0,0 +1,124 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	infrastructurev1alpha4 "github.com/vmware-tanzu/cluster-api-provider-byoh/apis/infrastructure/v1alpha4"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/cluster-api/api/v1alpha4"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newDrainTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := v1alpha4.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add cluster-api types to scheme: %v", err)
+	}
+	return scheme
+}
+
+func TestDrainNodeIsDoneWhenHostHasNoMachineRef(t *testing.T) {
+	r := &HostReconciler{Client: fake.NewClientBuilder().WithScheme(newDrainTestScheme(t)).Build()}
+	byoHost := &infrastructurev1alpha4.ByoHost{}
+
+	done, _, err := r.drainNode(context.Background(), byoHost)
+	if err != nil || !done {
+		t.Fatalf("drainNode() = (%v, _, %v), want (true, _, nil)", done, err)
+	}
+}
+
+func TestDrainNodeIsDoneWhenMachineIsGone(t *testing.T) {
+	r := &HostReconciler{Client: fake.NewClientBuilder().WithScheme(newDrainTestScheme(t)).Build()}
+	byoHost := &infrastructurev1alpha4.ByoHost{
+		Status: infrastructurev1alpha4.ByoHostStatus{
+			MachineRef: &corev1.ObjectReference{Name: "missing", Namespace: "default"},
+		},
+	}
+
+	done, _, err := r.drainNode(context.Background(), byoHost)
+	if err != nil || !done {
+		t.Fatalf("drainNode() = (%v, _, %v), want (true, _, nil)", done, err)
+	}
+}
+
+func TestDrainNodeIsDoneWhenMachineHasNoNodeRef(t *testing.T) {
+	machine := &v1alpha4.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "machine-1", Namespace: "default"},
+	}
+	r := &HostReconciler{Client: fake.NewClientBuilder().WithScheme(newDrainTestScheme(t)).WithObjects(machine).Build()}
+	byoHost := &infrastructurev1alpha4.ByoHost{
+		Status: infrastructurev1alpha4.ByoHostStatus{
+			MachineRef: &corev1.ObjectReference{Name: "machine-1", Namespace: "default"},
+		},
+	}
+
+	done, _, err := r.drainNode(context.Background(), byoHost)
+	if err != nil || !done {
+		t.Fatalf("drainNode() = (%v, _, %v), want (true, _, nil): kubelet never registered a Node, so there's nothing to drain", done, err)
+	}
+}
+
+// TestDrainNodeTakesTimeoutShortcutOnlyWithAnElapsedDeadline proves the two
+// sides of the elapsed-timeout fast path: a ByoHost with a deadline that has
+// already elapsed skips straight to done=true without touching the workload
+// cluster, while a ByoHost with no deadline never takes that shortcut -
+// regardless of how stale its DrainingSucceededCondition is - and instead
+// falls through to the remote cluster client, which fails loudly here
+// because there is no real workload cluster kubeconfig in this test.
+func TestDrainNodeTakesTimeoutShortcutOnlyWithAnElapsedDeadline(t *testing.T) {
+	machine := &v1alpha4.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "machine-1", Namespace: "default"},
+		Spec:       v1alpha4.MachineSpec{ClusterName: "cluster-1"},
+		Status:     v1alpha4.MachineStatus{NodeRef: &corev1.ObjectReference{Name: "node-1"}},
+	}
+	cluster := &v1alpha4.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-1", Namespace: "default"},
+	}
+	staleCondition := v1alpha4.Conditions{
+		{
+			Type:               infrastructurev1alpha4.DrainingSucceededCondition,
+			Status:             corev1.ConditionFalse,
+			Reason:             infrastructurev1alpha4.DrainingReason,
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+		},
+	}
+
+	t.Run("elapsed deadline takes the shortcut", func(t *testing.T) {
+		r := &HostReconciler{Client: fake.NewClientBuilder().WithScheme(newDrainTestScheme(t)).WithObjects(machine, cluster).Build()}
+		byoHost := &infrastructurev1alpha4.ByoHost{
+			Spec: infrastructurev1alpha4.ByoHostSpec{
+				NodeDrainTimeout: &metav1.Duration{Duration: time.Minute},
+			},
+			Status: infrastructurev1alpha4.ByoHostStatus{
+				MachineRef: &corev1.ObjectReference{Name: "machine-1", Namespace: "default"},
+				Conditions: staleCondition,
+			},
+		}
+
+		done, result, err := r.drainNode(context.Background(), byoHost)
+		if err != nil || !done || result != (ctrl.Result{}) {
+			t.Fatalf("drainNode() = (%v, %v, %v), want (true, {}, nil)", done, result, err)
+		}
+	})
+
+	t.Run("no deadline does not take the shortcut", func(t *testing.T) {
+		r := &HostReconciler{Client: fake.NewClientBuilder().WithScheme(newDrainTestScheme(t)).WithObjects(machine, cluster).Build()}
+		byoHost := &infrastructurev1alpha4.ByoHost{
+			Status: infrastructurev1alpha4.ByoHostStatus{
+				MachineRef: &corev1.ObjectReference{Name: "machine-1", Namespace: "default"},
+				Conditions: staleCondition,
+			},
+		}
+
+		done, _, err := r.drainNode(context.Background(), byoHost)
+		if done || err == nil {
+			t.Fatalf("drainNode() = (%v, _, %v), want (false, _, non-nil): a nil NodeDrainTimeout must never be treated as an elapsed deadline", done, err)
+		}
+	})
+}
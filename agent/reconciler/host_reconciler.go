@@ -2,21 +2,24 @@ package reconciler
 
 import (
 	"context"
+	"fmt"
 	"os"
 
 	"github.com/pkg/errors"
 	"github.com/vmware-tanzu/cluster-api-provider-byoh/agent/cloudinit"
+	"github.com/vmware-tanzu/cluster-api-provider-byoh/agent/hooks"
 	"github.com/vmware-tanzu/cluster-api-provider-byoh/agent/registration"
 	infrastructurev1alpha4 "github.com/vmware-tanzu/cluster-api-provider-byoh/apis/infrastructure/v1alpha4"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/klog"
+	"k8s.io/klog/v2"
 	"sigs.k8s.io/cluster-api/api/v1alpha4"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/predicates"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	"github.com/kube-vip/kube-vip/pkg/vip"
@@ -27,79 +30,136 @@ type HostReconciler struct {
 	CmdRunner      cloudinit.ICmdRunner
 	FileWriter     cloudinit.IFileWriter
 	TemplateParser cloudinit.ITemplateParser
+	// Hooks run, in order, around bootstrap and cleanup so operators can
+	// inject site-specific setup without patching the agent.
+	Hooks []hooks.BootstrapHook
 }
 
 const (
 	bootstrapSentinelFile = "/run/cluster-api/bootstrap-success.complete"
 	KubeadmResetCommand   = "kubeadm reset --force"
+
+	// HostFinalizer keeps a ByoHost around long enough for reconcileDelete to
+	// drain and reset the node before the API server removes it.
+	HostFinalizer = "byohost.infrastructure.cluster.x-k8s.io"
 )
 
+// defaultHooksDir is where the built-in ScriptDirHook looks for operator
+// drop-in scripts, mirroring kubelet's own drop-in config directories.
+const defaultHooksDir = "/etc/byoh/hooks.d"
+
+// NewHostReconciler builds a HostReconciler with the built-in script-dir
+// BootstrapHook registered, so operators can drop scripts under
+// defaultHooksDir without any further wiring.
+func NewHostReconciler(client client.Client, cmdRunner cloudinit.ICmdRunner, fileWriter cloudinit.IFileWriter, templateParser cloudinit.ITemplateParser) *HostReconciler {
+	return &HostReconciler{
+		Client:         client,
+		CmdRunner:      cmdRunner,
+		FileWriter:     fileWriter,
+		TemplateParser: templateParser,
+		Hooks:          []hooks.BootstrapHook{&hooks.ScriptDirHook{Dir: defaultHooksDir}},
+	}
+}
+
 func (r *HostReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
 	log := ctrl.LoggerFrom(ctx)
-	log.WithValues("byoHost ", req.Name)
 	log.Info("Reconciling byohost...")
 
 	// Fetch the ByoHost instance.
 	byoHost := &infrastructurev1alpha4.ByoHost{}
 	err := r.Client.Get(ctx, req.NamespacedName, byoHost)
 	if err != nil {
-		klog.Errorf("error getting ByoHost %s in namespace %s, err=%v", req.NamespacedName.Namespace, req.NamespacedName.Name, err)
+		log.Error(err, "error getting ByoHost", "byoHost", req.NamespacedName)
 		return ctrl.Result{}, err
 	}
 
+	// Enrich the logger with identifiers that every downstream call in this
+	// reconcile can be correlated by, and thread it back through the
+	// context so hostCleanUp, resetNode, bootstrapK8sNode etc. all log
+	// under the same keys.
+	log = log.WithValues("byoHost", klog.KObj(byoHost), "machineRef", byoHost.Status.MachineRef)
+	ctx = ctrl.LoggerInto(ctx, log)
+
 	helper, _ := patch.NewHelper(byoHost, r.Client)
 	defer func() {
 		if err = helper.Patch(ctx, byoHost); err != nil && reterr == nil {
-			klog.Errorf("failed to patch byohost, err=%v", err)
+			log.Error(err, "failed to patch byohost")
 			reterr = err
 		}
 	}()
 
+	// Handle deleted machines first: this must take priority over the
+	// cleanup annotation below, otherwise a ByoHost that is both recycling
+	// (annotation set) and being deleted (DeletionTimestamp set) would loop
+	// on the annotation branch forever and never reach finalizer removal,
+	// stuck Terminating.
+	if !byoHost.ObjectMeta.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, byoHost)
+	}
+
 	// Check for host cleanup annotation
 	hostAnnotations := byoHost.GetAnnotations()
 	_, ok := hostAnnotations[infrastructurev1alpha4.HostCleanupAnnotation]
 	if ok {
-		err = r.hostCleanUp(ctx, byoHost)
-		if err != nil {
+		return r.hostCleanUp(ctx, byoHost)
+	}
+
+	// Check for the certificate renewal annotation
+	if _, ok := hostAnnotations[infrastructurev1alpha4.RenewCertsAnnotation]; ok {
+		if err := r.reconcileCertRenewal(ctx, byoHost); err != nil {
 			return ctrl.Result{}, err
 		}
 		return ctrl.Result{}, nil
 	}
 
-	// Handle deleted machines
-	if !byoHost.ObjectMeta.DeletionTimestamp.IsZero() {
-		return r.reconcileDelete(ctx, byoHost)
+	if !controllerutil.ContainsFinalizer(byoHost, HostFinalizer) {
+		controllerutil.AddFinalizer(byoHost, HostFinalizer)
 	}
+
 	return r.reconcileNormal(ctx, byoHost)
 }
 
 func (r *HostReconciler) reconcileNormal(ctx context.Context, byoHost *infrastructurev1alpha4.ByoHost) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
 	if byoHost.Status.MachineRef == nil {
-		klog.Info("Machine ref not yet set")
+		log.Info("Machine ref not yet set")
 		conditions.MarkFalse(byoHost, infrastructurev1alpha4.K8sNodeBootstrapSucceeded, infrastructurev1alpha4.WaitingForMachineRefReason, v1alpha4.ConditionSeverityInfo, "")
 		return ctrl.Result{}, nil
 	}
 
 	if byoHost.Spec.BootstrapSecret == nil {
-		klog.Info("BootstrapDataSecret not ready")
+		log.Info("BootstrapDataSecret not ready")
 		conditions.MarkFalse(byoHost, infrastructurev1alpha4.K8sNodeBootstrapSucceeded, infrastructurev1alpha4.BootstrapDataSecretUnavailableReason, v1alpha4.ConditionSeverityInfo, "")
 		return ctrl.Result{}, nil
 	}
 
 	if !conditions.IsTrue(byoHost, infrastructurev1alpha4.K8sNodeBootstrapSucceeded) {
+		if err := r.runHooks(ctx, byoHost, hooks.PreBootstrap); err != nil {
+			log.Error(err, "pre-bootstrap hook failed")
+			return ctrl.Result{}, err
+		}
+
 		bootstrapScript, err := r.getBootstrapScript(ctx, byoHost.Spec.BootstrapSecret.Name, byoHost.Spec.BootstrapSecret.Namespace)
 		if err != nil {
-			klog.Errorf("error getting bootstrap script, err=%v", err)
+			log.Error(err, "error getting bootstrap script")
 			return ctrl.Result{}, err
 		}
-		err = r.bootstrapK8sNode(bootstrapScript, byoHost)
+		err = r.bootstrapK8sNode(ctx, bootstrapScript, byoHost)
 		if err != nil {
-			klog.Errorf("error in bootstrapping k8s node, err=%v", err)
-			_ = r.resetNode()
+			// Leave whatever the executor already wrote in place: its journal
+			// lets the next reconcile resume from the failed step instead of
+			// redoing a blanket kubeadm reset and starting the script over.
+			log.Error(err, "error in bootstrapping k8s node")
 			conditions.MarkFalse(byoHost, infrastructurev1alpha4.K8sNodeBootstrapSucceeded, infrastructurev1alpha4.CloudInitExecutionFailedReason, v1alpha4.ConditionSeverityError, "")
 			return ctrl.Result{}, err
 		}
-		klog.Info("k8s node successfully bootstrapped")
+		log.Info("k8s node successfully bootstrapped")
+
+		if err := r.runHooks(ctx, byoHost, hooks.PostBootstrap); err != nil {
+			log.Error(err, "post-bootstrap hook failed")
+			return ctrl.Result{}, err
+		}
 
 		conditions.MarkTrue(byoHost, infrastructurev1alpha4.K8sNodeBootstrapSucceeded)
 	}
@@ -107,7 +167,31 @@ func (r *HostReconciler) reconcileNormal(ctx context.Context, byoHost *infrastru
 	return ctrl.Result{}, nil
 }
 
+// runHooks invokes every registered BootstrapHook for the given phase, in
+// registration order. The first hook to fail marks HooksSucceeded false with
+// its name and short-circuits the remaining hooks.
+func (r *HostReconciler) runHooks(ctx context.Context, byoHost *infrastructurev1alpha4.ByoHost, phase hooks.Phase) error {
+	for _, hook := range r.Hooks {
+		if err := hook.Run(ctx, byoHost, phase); err != nil {
+			conditions.MarkFalse(byoHost, infrastructurev1alpha4.HooksSucceeded, infrastructurev1alpha4.HookFailedReason, v1alpha4.ConditionSeverityError,
+				"hook %q failed during %s: %v", hook.Name(), phase, err)
+			return errors.Wrapf(err, "bootstrap hook %q failed during %s", hook.Name(), phase)
+		}
+	}
+	conditions.MarkTrue(byoHost, infrastructurev1alpha4.HooksSucceeded)
+	return nil
+}
+
 func (r *HostReconciler) reconcileDelete(ctx context.Context, byoHost *infrastructurev1alpha4.ByoHost) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	result, err := r.hostCleanUp(ctx, byoHost)
+	if err != nil || result != (ctrl.Result{}) {
+		return result, err
+	}
+
+	controllerutil.RemoveFinalizer(byoHost, HostFinalizer)
+	log.Info("ByoHost drained and cleaned up, removing finalizer")
 	return ctrl.Result{}, nil
 }
 
@@ -129,17 +213,36 @@ func (r *HostReconciler) SetupWithManager(ctx context.Context, mgr manager.Manag
 		Complete(r)
 }
 
-func (r HostReconciler) hostCleanUp(ctx context.Context, byoHost *infrastructurev1alpha4.ByoHost) error {
-	err := r.resetNode()
-	if err != nil {
-		return err
+// hostCleanUp drains the workload cluster node backed by byoHost (if any),
+// then resets and releases the host for reuse. It is called both from
+// reconcileDelete, via the ByoHost's DeletionTimestamp, and directly from
+// Reconcile when HostCleanupAnnotation is set to recycle a host without
+// deleting it - both paths must drain before resetting.
+func (r HostReconciler) hostCleanUp(ctx context.Context, byoHost *infrastructurev1alpha4.ByoHost) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	done, result, err := r.drainNode(ctx, byoHost)
+	if err != nil || !done {
+		return result, err
+	}
+
+	if err := r.runHooks(ctx, byoHost, hooks.PreCleanup); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.cleanupBootstrapArtifacts(ctx, byoHost); err != nil {
+		log.Error(err, "failed to clean up bootstrap artifacts from journal")
 	}
 
-	klog.Info("Removing the bootstrap sentinel file...")
+	if err := r.resetNode(ctx); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	log.Info("Removing the bootstrap sentinel file...")
 	if _, err := os.Stat(bootstrapSentinelFile); !os.IsNotExist(err) {
 		err := os.Remove(bootstrapSentinelFile)
 		if err != nil {
-			return errors.Wrapf(err, "failed to delete sentinel file %s", bootstrapSentinelFile)
+			return ctrl.Result{}, errors.Wrapf(err, "failed to delete sentinel file %s", bootstrapSentinelFile)
 		}
 	}
 
@@ -148,7 +251,7 @@ func (r HostReconciler) hostCleanUp(ctx context.Context, byoHost *infrastructure
 		if err == nil {
 			err := network.DeleteIP()
 			if err != nil {
-				return err
+				return ctrl.Result{}, err
 			}
 		}
 	}
@@ -156,6 +259,11 @@ func (r HostReconciler) hostCleanUp(ctx context.Context, byoHost *infrastructure
 	// Remove host reservation.
 	byoHost.Status.MachineRef = nil
 
+	// Clear the prior lifecycle's draining condition so a recycled host's
+	// next drain gets a fresh LastTransitionTime instead of immediately
+	// reading as timed-out against a deadline that elapsed lifecycles ago.
+	conditions.Delete(byoHost, infrastructurev1alpha4.DrainingSucceededCondition)
+
 	// Remove cluster-name label
 	delete(byoHost.Labels, v1alpha4.ClusterLabelName)
 
@@ -169,24 +277,52 @@ func (r HostReconciler) hostCleanUp(ctx context.Context, byoHost *infrastructure
 	delete(byoHost.Annotations, infrastructurev1alpha4.ClusterVersionAnnotation)
 
 	conditions.MarkFalse(byoHost, infrastructurev1alpha4.K8sNodeBootstrapSucceeded, infrastructurev1alpha4.K8sNodeAbsentReason, v1alpha4.ConditionSeverityInfo, "")
-	return nil
+
+	if err := r.runHooks(ctx, byoHost, hooks.PostCleanup); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
 }
 
-func (r *HostReconciler) resetNode() error {
-	klog.Info("Running kubeadm reset...")
+func (r *HostReconciler) resetNode(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx)
+	log.Info("Running kubeadm reset...")
 
 	err := r.CmdRunner.RunCmd(KubeadmResetCommand)
 	if err != nil {
 		return errors.Wrapf(err, "failed to exec kubeadm reset")
 	}
 
-	klog.Info("Kubernetes Node reset")
+	log.Info("Kubernetes Node reset")
 	return nil
 }
 
-func (r *HostReconciler) bootstrapK8sNode(bootstrapScript string, byoHost *infrastructurev1alpha4.ByoHost) error {
+// cleanupBootstrapArtifacts removes exactly the files the bootstrap
+// executor's journal recorded it created, rather than relying on kubeadm
+// reset to take care of everything the agent itself wrote.
+func (r HostReconciler) cleanupBootstrapArtifacts(ctx context.Context, byoHost *infrastructurev1alpha4.ByoHost) error {
+	if byoHost.Spec.BootstrapSecret == nil {
+		return nil
+	}
+
+	bootstrapScript, err := r.getBootstrapScript(ctx, byoHost.Spec.BootstrapSecret.Name, byoHost.Spec.BootstrapSecret.Namespace)
+	if err != nil {
+		return err
+	}
+
+	return cloudinit.ScriptExecutor{
+		WriteFilesExecutor:    r.FileWriter,
+		RunCmdExecutor:        r.CmdRunner,
+		ParseTemplateExecutor: r.TemplateParser}.Cleanup(ctx, bootstrapScript)
+}
+
+func (r *HostReconciler) bootstrapK8sNode(ctx context.Context, bootstrapScript string, byoHost *infrastructurev1alpha4.ByoHost) error {
+	onProgress := func(step, total int) {
+		byoHost.Status.BootstrapProgress = fmt.Sprintf("%d/%d", step, total)
+	}
 	return cloudinit.ScriptExecutor{
 		WriteFilesExecutor:    r.FileWriter,
 		RunCmdExecutor:        r.CmdRunner,
-		ParseTemplateExecutor: r.TemplateParser}.Execute(bootstrapScript)
+		ParseTemplateExecutor: r.TemplateParser}.Execute(ctx, bootstrapScript, onProgress)
 }
@@ -0,0 +1,180 @@
+// Package v1alpha4 contains API Schema definitions for the
+// infrastructure v1alpha4 API group.
+package v1alpha4
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha4"
+)
+
+const (
+	// HostCleanupAnnotation, when present on a ByoHost, tells the agent to
+	// reset the node and release the host for reuse without waiting for the
+	// Machine/ByoHost to be deleted.
+	HostCleanupAnnotation = "byoh.infrastructure.cluster.x-k8s.io/unregistering"
+
+	// EndPointIPAnnotation records the kube-vip endpoint IP a control-plane
+	// ByoHost is fronting, so hostCleanUp can release it.
+	EndPointIPAnnotation = "byoh.infrastructure.cluster.x-k8s.io/endpoint-ip"
+
+	// ClusterVersionAnnotation records the Kubernetes version the host was
+	// last bootstrapped with.
+	ClusterVersionAnnotation = "byoh.infrastructure.cluster.x-k8s.io/k8s-version"
+
+	// RenewCertsAnnotation, when present on a control-plane ByoHost, tells
+	// the agent to renew its kubeadm-issued certificates on the next
+	// reconcile.
+	RenewCertsAnnotation = "byoh.infrastructure.cluster.x-k8s.io/renew-certs"
+)
+
+// ConditionTypes and Reasons reported on ByoHost.Status.Conditions.
+const (
+	// K8sNodeBootstrapSucceeded documents the state of the bootstrap script
+	// execution.
+	K8sNodeBootstrapSucceeded clusterv1.ConditionType = "K8sNodeBootstrapSucceeded"
+
+	// WaitingForMachineRefReason is used when a ByoHost has not yet been
+	// claimed by a Machine.
+	WaitingForMachineRefReason = "WaitingForMachineRef"
+	// BootstrapDataSecretUnavailableReason is used when the Machine's
+	// bootstrap data secret is not yet available.
+	BootstrapDataSecretUnavailableReason = "BootstrapDataSecretUnavailable"
+	// CloudInitExecutionFailedReason is used when the bootstrap script
+	// failed to apply.
+	CloudInitExecutionFailedReason = "CloudInitExecutionFailed"
+	// K8sNodeAbsentReason is used once a host has been cleaned up and no
+	// longer runs a kubelet.
+	K8sNodeAbsentReason = "K8sNodeAbsent"
+
+	// DrainingSucceededCondition documents whether the workload cluster
+	// node backed by this host has been cordoned and drained.
+	DrainingSucceededCondition clusterv1.ConditionType = "DrainingSucceeded"
+	// DrainingReason is used while a drain is in progress.
+	DrainingReason = "Draining"
+
+	// HooksSucceeded documents whether every registered BootstrapHook ran
+	// successfully for the most recent phase.
+	HooksSucceeded clusterv1.ConditionType = "HooksSucceeded"
+	// HookFailedReason is used when a BootstrapHook returns an error.
+	HookFailedReason = "HookFailed"
+
+	// HostHealthy documents the host's current inventory/health signals as
+	// collected by the periodic HostStatusReporter.
+	HostHealthy clusterv1.ConditionType = "HostHealthy"
+	// HostInfoUnavailableReason is used when one or more health signals
+	// could not be collected; the signals that were collected are still
+	// reported in HostInfo.
+	HostInfoUnavailableReason = "HostInfoUnavailable"
+	// HostDegradedReason is used when health signals were collected and
+	// show the host under pressure, or its kubelet unhealthy.
+	HostDegradedReason = "HostDegraded"
+
+	// CertificatesReady documents the state of the host's kubeadm-issued
+	// certificates, for control-plane hosts.
+	CertificatesReady clusterv1.ConditionType = "CertificatesReady"
+	// CertificateRenewalFailedReason is used when a certificate renewal
+	// request failed.
+	CertificateRenewalFailedReason = "CertificateRenewalFailed"
+	// CertificatesExpiringReason is used when certificates are still valid
+	// but within their renewal window.
+	CertificatesExpiringReason = "CertificatesExpiring"
+)
+
+// HostInfo is a snapshot of host inventory and health signals collected by
+// the agent's periodic HostStatusReporter.
+type HostInfo struct {
+	// KernelVersion is the host's `uname -r` release string.
+	KernelVersion string `json:"kernelVersion,omitempty"`
+	// OSImage is the host's PRETTY_NAME from /etc/os-release.
+	OSImage string `json:"osImage,omitempty"`
+	// CPUPressure is true when the host's load average indicates CPU
+	// contention relative to its core count.
+	CPUPressure bool `json:"cpuPressure,omitempty"`
+	// MemoryPressure is true when the host is low on available memory.
+	MemoryPressure bool `json:"memoryPressure,omitempty"`
+	// DiskPressure is true when the host's root filesystem is low on free
+	// space.
+	DiskPressure bool `json:"diskPressure,omitempty"`
+	// ContainerRuntimeVersion is the trimmed output of the detected
+	// container runtime CLI's `--version` command, e.g. `containerd --version`.
+	ContainerRuntimeVersion string `json:"containerRuntimeVersion,omitempty"`
+	// KubeletHealthy is the result of probing the kubelet's /healthz
+	// endpoint, once a node is expected to be running one.
+	KubeletHealthy bool `json:"kubeletHealthy,omitempty"`
+	// NetworkInterfaces lists the names of the host's up, non-loopback
+	// network interfaces that have at least one address.
+	NetworkInterfaces []string `json:"networkInterfaces,omitempty"`
+}
+
+// ByoHostSpec defines the desired state of a ByoHost.
+type ByoHostSpec struct {
+	// BootstrapSecret is a reference to the Machine's bootstrap data
+	// secret, set once a Machine claims this host.
+	// +optional
+	BootstrapSecret *corev1.ObjectReference `json:"bootstrapSecret,omitempty"`
+
+	// NodeDrainTimeout bounds how long reconcileDelete waits for the
+	// workload cluster node to drain before proceeding with kubeadm reset
+	// anyway. Nil or zero means wait indefinitely, mirroring
+	// Machine.Spec.NodeDrainTimeout upstream.
+	// +optional
+	NodeDrainTimeout *metav1.Duration `json:"nodeDrainTimeout,omitempty"`
+}
+
+// ByoHostStatus defines the observed state of a ByoHost.
+type ByoHostStatus struct {
+	// MachineRef is a reference to the Machine that has claimed this host.
+	// +optional
+	MachineRef *corev1.ObjectReference `json:"machineRef,omitempty"`
+
+	// BootstrapProgress reports "<applied steps>/<total steps>" of the
+	// current bootstrap script execution.
+	// +optional
+	BootstrapProgress string `json:"bootstrapProgress,omitempty"`
+
+	// HostInfo is the most recently collected host inventory/health
+	// snapshot.
+	// +optional
+	HostInfo HostInfo `json:"hostInfo,omitempty"`
+
+	// CertificatesExpiryDate is the earliest expiration date among this
+	// control-plane host's kubeadm-issued certificates.
+	// +optional
+	CertificatesExpiryDate *metav1.Time `json:"certificatesExpiryDate,omitempty"`
+
+	// Conditions defines current service state of the ByoHost.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// ByoHost is the Schema for the byohosts API.
+type ByoHost struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ByoHostSpec   `json:"spec,omitempty"`
+	Status ByoHostStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the set of conditions for this object.
+func (b *ByoHost) GetConditions() clusterv1.Conditions {
+	return b.Status.Conditions
+}
+
+// SetConditions sets the conditions on this object.
+func (b *ByoHost) SetConditions(conditions clusterv1.Conditions) {
+	b.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// ByoHostList contains a list of ByoHost.
+type ByoHostList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ByoHost `json:"items"`
+}